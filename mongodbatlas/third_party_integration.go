@@ -2,6 +2,7 @@ package mongodbatlas
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 )
@@ -15,11 +16,45 @@ const (
 
 // See more: https://docs.atlas.mongodb.com/reference/api/third-party-integration-settings/
 type IntegrationsService interface {
-	Create(context.Context, string, string, *ThirdPartyService) (*IntegrationResponse, *Response, error)
-	Replace(context.Context, string, string, *ThirdPartyService) (*IntegrationResponse, *Response, error)
-	Delete(context.Context, string, string) (*Response, error)
-	Get(context.Context, string, string) (*ThirdPartyService, *Response, error)
-	List(context.Context, string) (*IntegrationResponse, *Response, error)
+	Create(context.Context, string, ThirdPartyIntegration, *CreateOptions) (*IntegrationResponse, *Response, error)
+	Replace(context.Context, string, ThirdPartyIntegration, *ReplaceOptions) (*IntegrationResponse, *Response, error)
+	Delete(context.Context, string, string, *DeleteOptions) (*Response, error)
+	Get(context.Context, string, string) (ThirdPartyIntegration, *Response, error)
+	List(context.Context, string, *ListOptions) (*IntegrationResponse, *Response, error)
+}
+
+// CreateOptions are the options for IntegrationsServiceOp.Create.
+type CreateOptions struct {
+	// ValidateOnly, when true, asks Atlas to validate the integration
+	// configuration without actually creating it.
+	ValidateOnly bool `url:"validateOnly,omitempty"`
+}
+
+// ReplaceOptions are the options for IntegrationsServiceOp.Replace.
+type ReplaceOptions struct {
+	// ValidateOnly, when true, asks Atlas to validate the integration
+	// configuration without actually applying it.
+	ValidateOnly bool `url:"validateOnly,omitempty"`
+}
+
+// DeleteOptions are the options for IntegrationsServiceOp.Delete.
+type DeleteOptions struct {
+	// ValidateOnly, when true, asks Atlas to validate the deletion without
+	// actually removing the integration.
+	ValidateOnly bool `url:"validateOnly,omitempty"`
+}
+
+// withValidateOnly runs opts (a *CreateOptions, *ReplaceOptions, or
+// *DeleteOptions) through setQueryParams, then appends the
+// pretty=false&envelope=false Atlas also expects for a dry-run integration
+// mutation.
+func withValidateOnly(path string, opts interface{}) (string, error) {
+	path, err := setQueryParams(path, opts)
+	if err != nil {
+		return "", err
+	}
+
+	return path + "&pretty=false&envelope=false", nil
 }
 
 // TeamsServiceOp handles communication with the Teams related methods of the
@@ -28,28 +63,159 @@ type IntegrationsServiceOp service
 
 var _ IntegrationsService = &IntegrationsServiceOp{}
 
-// IntegrationRequest contains parameters for different third-party services
+// ThirdPartyService contains parameters for different third-party services.
+//
+// Deprecated: ThirdPartyService flattens the fields of every integration
+// provider into a single struct, which makes it impossible to tell which
+// fields a given integration actually requires. Use the typed
+// ThirdPartyIntegration implementations (DatadogIntegration,
+// PagerDutyIntegration, SlackIntegration, NewRelicIntegration,
+// OpsGenieIntegration, VictorOpsIntegration, WebhookIntegration,
+// MicrosoftTeamsIntegration, PrometheusIntegration) with
+// IntegrationsService.Create/Replace/Get/List instead. This type and the
+// CreateThirdPartyService/ReplaceThirdPartyService/GetThirdPartyService/
+// ListThirdPartyServices methods are kept for backwards compatibility.
 type ThirdPartyService struct {
-	Type        string `json:"type,omitempty"`
-	LicenseKey  string `json:"licenseKey,omitempty"`
-	AccountId   string `json:"accountId,omitempty"`
-	WriteToken  string `json:"writeToken,omitempty"`
-	ReadToken   string `json:"readToken,omitempty"`
-	ApiKey      string `json:"apiKey,omitempty"`
-	Region      string `json:"region,omitempty"`
-	ServiceKey  string `json:"serviceKey,omitempty"`
-	ApiToken    string `json:"apiToken,omitempty"`
-	TeamName    string `json:"teamName,omitempty"`
-	ChannelName string `json:"channelName,omitempty"`
-	RoutingKey  string `json:"routingKey,omitempty"`
-	FlowName    string `json:"flowName,omitempty"`
-	OrgName     string `json:"orgName,omitempty"`
-	Url         string `json:"url,omitempty"`
-	Secret      string `json:"secret,omitempty"`
+	Type                     string `json:"type,omitempty"`
+	LicenseKey               string `json:"licenseKey,omitempty"`
+	AccountId                string `json:"accountId,omitempty"`
+	WriteToken               string `json:"writeToken,omitempty"`
+	ReadToken                string `json:"readToken,omitempty"`
+	ApiKey                   string `json:"apiKey,omitempty"`
+	Region                   string `json:"region,omitempty"`
+	ServiceKey               string `json:"serviceKey,omitempty"`
+	ApiToken                 string `json:"apiToken,omitempty"`
+	TeamName                 string `json:"teamName,omitempty"`
+	ChannelName              string `json:"channelName,omitempty"`
+	RoutingKey               string `json:"routingKey,omitempty"`
+	FlowName                 string `json:"flowName,omitempty"`
+	OrgName                  string `json:"orgName,omitempty"`
+	Url                      string `json:"url,omitempty"`
+	Secret                   string `json:"secret,omitempty"`
+	Username                 string `json:"username,omitempty"`
+	Password                 string `json:"password,omitempty"`
+	ServiceDiscovery         string `json:"serviceDiscovery,omitempty"`
+	Scheme                   string `json:"scheme,omitempty"`
+	Enabled                  *bool  `json:"enabled,omitempty"`
+	TlsPemPath               string `json:"tlsPemPath,omitempty"`
+	MicrosoftTeamsWebhookUrl string `json:"microsoftTeamsWebhookUrl,omitempty"`
+}
+
+// requiredThirdPartyServiceFields maps each integration type to the
+// ThirdPartyService fields the Atlas API requires for it.
+var requiredThirdPartyServiceFields = map[string][]string{
+	IntegrationTypeDatadog:        {"ApiKey"},
+	IntegrationTypePagerDuty:      {"ServiceKey"},
+	IntegrationTypeSlack:          {"ApiToken", "ChannelName"},
+	IntegrationTypeNewRelic:       {"LicenseKey", "AccountId", "WriteToken", "ReadToken"},
+	IntegrationTypeOpsGenie:       {"ApiKey"},
+	IntegrationTypeVictorOps:      {"ApiKey"},
+	IntegrationTypeWebhook:        {"Url"},
+	IntegrationTypeMicrosoftTeams: {"MicrosoftTeamsWebhookUrl"},
+	IntegrationTypePrometheus:     {"Username", "Password", "ServiceDiscovery", "Scheme"},
+}
+
+// Validate checks that the fields Atlas requires for s.Type are set.
+//
+// Deprecated: each typed ThirdPartyIntegration implementation has its own
+// Validate method, which Create and Replace call before sending the request.
+func (s *ThirdPartyService) Validate() error {
+	if s.Type == "" {
+		return NewArgError("Type", "must be set")
+	}
+
+	required, ok := requiredThirdPartyServiceFields[s.Type]
+	if !ok {
+		return nil
+	}
+
+	for _, field := range required {
+		if thirdPartyServiceFieldIsEmpty(s, field) {
+			return NewArgError(field, fmt.Sprintf("must be set for %s integrations", s.Type))
+		}
+	}
+
+	return nil
+}
+
+// thirdPartyServiceFieldIsEmpty reports whether the named ThirdPartyService
+// field holds its zero value.
+func thirdPartyServiceFieldIsEmpty(s *ThirdPartyService, field string) bool {
+	switch field {
+	case "ApiKey":
+		return s.ApiKey == ""
+	case "ServiceKey":
+		return s.ServiceKey == ""
+	case "ApiToken":
+		return s.ApiToken == ""
+	case "ChannelName":
+		return s.ChannelName == ""
+	case "LicenseKey":
+		return s.LicenseKey == ""
+	case "AccountId":
+		return s.AccountId == ""
+	case "WriteToken":
+		return s.WriteToken == ""
+	case "ReadToken":
+		return s.ReadToken == ""
+	case "Url":
+		return s.Url == ""
+	case "MicrosoftTeamsWebhookUrl":
+		return s.MicrosoftTeamsWebhookUrl == ""
+	case "Username":
+		return s.Username == ""
+	case "Password":
+		return s.Password == ""
+	case "ServiceDiscovery":
+		return s.ServiceDiscovery == ""
+	case "Scheme":
+		return s.Scheme == ""
+	default:
+		return false
+	}
 }
 
 // IntegrationResponse contains the response from the endpoint
 type IntegrationResponse struct {
+	Links      []*Link                 `json:"links"`
+	Results    []ThirdPartyIntegration `json:"results"`
+	TotalCount int                     `json:"totalCount"`
+}
+
+// UnmarshalJSON implements json.Unmarshaler. Each element of results is
+// dispatched to its concrete ThirdPartyIntegration type based on its "type"
+// discriminator.
+func (r *IntegrationResponse) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Links      []*Link           `json:"links"`
+		Results    []json.RawMessage `json:"results"`
+		TotalCount int               `json:"totalCount"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	results := make([]ThirdPartyIntegration, 0, len(raw.Results))
+	for _, rawResult := range raw.Results {
+		integration, err := unmarshalThirdPartyIntegration(rawResult)
+		if err != nil {
+			return err
+		}
+		results = append(results, integration)
+	}
+
+	r.Links = raw.Links
+	r.Results = results
+	r.TotalCount = raw.TotalCount
+
+	return nil
+}
+
+// ThirdPartyServiceResponse contains the response from the endpoint using the
+// deprecated ThirdPartyService representation.
+//
+// Deprecated: use IntegrationResponse instead.
+type ThirdPartyServiceResponse struct {
 	Links      []*Link              `json:"links"`
 	Results    []*ThirdPartyService `json:"results"`
 	TotalCount int                  `json:"totalCount"`
@@ -58,17 +224,29 @@ type IntegrationResponse struct {
 // Create adds a new third-party integration configuration.
 //
 // See more: https://docs.atlas.mongodb.com/reference/api/third-party-integration-settings-create/index.html
-func (s *IntegrationsServiceOp) Create(ctx context.Context, projectID, integrationType string, body *ThirdPartyService) (*IntegrationResponse, *Response, error) {
+func (s *IntegrationsServiceOp) Create(ctx context.Context, projectID string, body ThirdPartyIntegration, opts *CreateOptions) (*IntegrationResponse, *Response, error) {
 	if projectID == "" {
 		return nil, nil, NewArgError("projectID", "must be set")
 	}
 
-	if integrationType == "" {
-		return nil, nil, NewArgError("integrationType", "must be set")
+	if body == nil {
+		return nil, nil, NewArgError("body", "must be set")
+	}
+
+	if v, ok := body.(validatableIntegration); ok {
+		if err := v.Validate(); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	basePath := fmt.Sprintf(integrationBasePath, projectID)
-	path := fmt.Sprintf("%s/%s", basePath, integrationType)
+	path := fmt.Sprintf("%s/%s", basePath, body.Type())
+	if opts != nil && opts.ValidateOnly {
+		var err error
+		if path, err = withValidateOnly(path, opts); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, body)
 	if err != nil {
@@ -91,17 +269,29 @@ func (s *IntegrationsServiceOp) Create(ctx context.Context, projectID, integrati
 // Replace replaces the third-party integration configuration with a new configuration, or add a new configuration if there is no configuration.
 //
 // https://docs.atlas.mongodb.com/reference/api/third-party-integration-settings-update/
-func (s *IntegrationsServiceOp) Replace(ctx context.Context, projectID, integrationType string, body *ThirdPartyService) (*IntegrationResponse, *Response, error) {
+func (s *IntegrationsServiceOp) Replace(ctx context.Context, projectID string, body ThirdPartyIntegration, opts *ReplaceOptions) (*IntegrationResponse, *Response, error) {
 	if projectID == "" {
 		return nil, nil, NewArgError("projectID", "must be set")
 	}
 
-	if integrationType == "" {
-		return nil, nil, NewArgError("integrationType", "must be set")
+	if body == nil {
+		return nil, nil, NewArgError("body", "must be set")
+	}
+
+	if v, ok := body.(validatableIntegration); ok {
+		if err := v.Validate(); err != nil {
+			return nil, nil, err
+		}
 	}
 
 	basePath := fmt.Sprintf(integrationBasePath, projectID)
-	path := fmt.Sprintf("%s/%s", basePath, integrationType)
+	path := fmt.Sprintf("%s/%s", basePath, body.Type())
+	if opts != nil && opts.ValidateOnly {
+		var err error
+		if path, err = withValidateOnly(path, opts); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, body)
 	if err != nil {
@@ -124,7 +314,7 @@ func (s *IntegrationsServiceOp) Replace(ctx context.Context, projectID, integrat
 // Delete removes the third-party integration configuration
 //
 // https://docs.atlas.mongodb.com/reference/api/third-party-integration-settings-delete/
-func (s *IntegrationsServiceOp) Delete(ctx context.Context, projectID, integrationType string) (*Response, error) {
+func (s *IntegrationsServiceOp) Delete(ctx context.Context, projectID, integrationType string, opts *DeleteOptions) (*Response, error) {
 	if projectID == "" {
 		return nil, NewArgError("projectID", "must be set")
 	}
@@ -135,6 +325,12 @@ func (s *IntegrationsServiceOp) Delete(ctx context.Context, projectID, integrati
 
 	basePath := fmt.Sprintf(integrationBasePath, projectID)
 	path := fmt.Sprintf("%s/%s", basePath, integrationType)
+	if opts != nil && opts.ValidateOnly {
+		var err error
+		if path, err = withValidateOnly(path, opts); err != nil {
+			return nil, err
+		}
+	}
 
 	req, err := s.Client.NewRequest(ctx, http.MethodDelete, path, nil)
 	if err != nil {
@@ -142,13 +338,17 @@ func (s *IntegrationsServiceOp) Delete(ctx context.Context, projectID, integrati
 	}
 
 	resp, err := s.Client.Do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
 	return resp, nil
 }
 
 // Get retrieves a specific third-party integration configuration
 //
 // https://docs.atlas.mongodb.com/reference/api/third-party-integration-settings-get-one/
-func (s *IntegrationsServiceOp) Get(ctx context.Context, projectID, integrationType string) (*ThirdPartyService, *Response, error) {
+func (s *IntegrationsServiceOp) Get(ctx context.Context, projectID, integrationType string) (ThirdPartyIntegration, *Response, error) {
 	if projectID == "" {
 		return nil, nil, NewArgError("projectID", "must be set")
 	}
@@ -165,24 +365,33 @@ func (s *IntegrationsServiceOp) Get(ctx context.Context, projectID, integrationT
 		return nil, nil, err
 	}
 
-	root := new(ThirdPartyService)
-	resp, err := s.Client.Do(ctx, req, root)
+	var raw json.RawMessage
+	resp, err := s.Client.Do(ctx, req, &raw)
 	if err != nil {
 		return nil, resp, err
 	}
 
-	return root, resp, nil
+	integration, err := unmarshalThirdPartyIntegration(raw)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return integration, resp, nil
 }
 
 // List retrieves all third-party integration configurations.
 //
 // See more: https://docs.atlas.mongodb.com/reference/api/third-party-integration-settings-get-all/
-func (s *IntegrationsServiceOp) List(ctx context.Context, projectID string) (*IntegrationResponse, *Response, error) {
+func (s *IntegrationsServiceOp) List(ctx context.Context, projectID string, opts *ListOptions) (*IntegrationResponse, *Response, error) {
 	if projectID == "" {
 		return nil, nil, NewArgError("projectID", "must be set")
 	}
 
 	path := fmt.Sprintf(integrationBasePath, projectID)
+	path, err := setListOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
 	if err != nil {
@@ -201,3 +410,150 @@ func (s *IntegrationsServiceOp) List(ctx context.Context, projectID string) (*In
 
 	return root, resp, nil
 }
+
+// CreateThirdPartyService adds a new third-party integration configuration
+// using the flattened ThirdPartyService representation.
+//
+// Deprecated: use Create with a typed ThirdPartyIntegration instead.
+func (s *IntegrationsServiceOp) CreateThirdPartyService(ctx context.Context, projectID, integrationType string, body *ThirdPartyService) (*ThirdPartyServiceResponse, *Response, error) {
+	if projectID == "" {
+		return nil, nil, NewArgError("projectID", "must be set")
+	}
+
+	if integrationType == "" {
+		return nil, nil, NewArgError("integrationType", "must be set")
+	}
+
+	if body.Type == "" {
+		body.Type = integrationType
+	}
+
+	if err := body.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	basePath := fmt.Sprintf(integrationBasePath, projectID)
+	path := fmt.Sprintf("%s/%s", basePath, integrationType)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPost, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(ThirdPartyServiceResponse)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
+}
+
+// ReplaceThirdPartyService replaces a third-party integration configuration
+// using the flattened ThirdPartyService representation.
+//
+// Deprecated: use Replace with a typed ThirdPartyIntegration instead.
+func (s *IntegrationsServiceOp) ReplaceThirdPartyService(ctx context.Context, projectID, integrationType string, body *ThirdPartyService) (*ThirdPartyServiceResponse, *Response, error) {
+	if projectID == "" {
+		return nil, nil, NewArgError("projectID", "must be set")
+	}
+
+	if integrationType == "" {
+		return nil, nil, NewArgError("integrationType", "must be set")
+	}
+
+	if body.Type == "" {
+		body.Type = integrationType
+	}
+
+	if err := body.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	basePath := fmt.Sprintf(integrationBasePath, projectID)
+	path := fmt.Sprintf("%s/%s", basePath, integrationType)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodPut, path, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(ThirdPartyServiceResponse)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
+}
+
+// GetThirdPartyService retrieves a specific third-party integration
+// configuration using the flattened ThirdPartyService representation.
+//
+// Deprecated: use Get with a typed ThirdPartyIntegration instead.
+func (s *IntegrationsServiceOp) GetThirdPartyService(ctx context.Context, projectID, integrationType string) (*ThirdPartyService, *Response, error) {
+	if projectID == "" {
+		return nil, nil, NewArgError("projectID", "must be set")
+	}
+
+	if integrationType == "" {
+		return nil, nil, NewArgError("integrationType", "must be set")
+	}
+
+	basePath := fmt.Sprintf(integrationBasePath, projectID)
+	path := fmt.Sprintf("%s/%s", basePath, integrationType)
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(ThirdPartyService)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return root, resp, nil
+}
+
+// ListThirdPartyServices retrieves all third-party integration configurations
+// using the flattened ThirdPartyService representation.
+//
+// Deprecated: use List with a typed ThirdPartyIntegration instead.
+func (s *IntegrationsServiceOp) ListThirdPartyServices(ctx context.Context, projectID string, opts *ListOptions) (*ThirdPartyServiceResponse, *Response, error) {
+	if projectID == "" {
+		return nil, nil, NewArgError("projectID", "must be set")
+	}
+
+	path := fmt.Sprintf(integrationBasePath, projectID)
+	path, err := setListOptions(path, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	req, err := s.Client.NewRequest(ctx, http.MethodGet, path, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	root := new(ThirdPartyServiceResponse)
+	resp, err := s.Client.Do(ctx, req, root)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if l := root.Links; l != nil {
+		resp.Links = l
+	}
+
+	return root, resp, nil
+}
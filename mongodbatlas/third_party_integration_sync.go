@@ -0,0 +1,180 @@
+package mongodbatlas
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"sort"
+)
+
+// SyncOptions are the options for IntegrationsServiceOp.Sync.
+type SyncOptions struct {
+	// DryRun, when true, computes the sync plan without creating, replacing,
+	// or deleting any integration.
+	DryRun bool
+
+	// PreserveUnknown, when true, leaves integrations that exist in Atlas but
+	// are not present in desired alone instead of deleting them.
+	PreserveUnknown bool
+}
+
+// SyncResult describes the effect, or planned effect, of a call to
+// IntegrationsServiceOp.Sync.
+type SyncResult struct {
+	Created   []ThirdPartyIntegration
+	Updated   []ThirdPartyIntegration
+	Unchanged []ThirdPartyIntegration
+	Deleted   []ThirdPartyIntegration
+}
+
+// syncRedactedFields lists the JSON field names Atlas returns obfuscated on
+// read (e.g. "****"), which Sync must ignore when deciding whether a desired
+// integration differs from the one Atlas already has.
+var syncRedactedFields = map[string]bool{
+	"apiKey":     true,
+	"licenseKey": true,
+	"password":   true,
+	"secret":     true,
+	"serviceKey": true,
+	"apiToken":   true,
+	"writeToken": true,
+	"readToken":  true,
+}
+
+// Sync reconciles the project's third-party integrations with desired: it
+// lists the integrations Atlas currently has, then creates any integration
+// type present in desired but missing from Atlas, replaces any whose
+// relevant fields differ (ignoring server-obfuscated secret fields such as
+// apiKey/licenseKey), and, unless opts.PreserveUnknown is set, deletes any
+// integration Atlas has that desired does not mention. Integration types are
+// processed in a deterministic, sorted order. If opts.DryRun is set, Sync
+// returns the plan it would have applied without calling Create, Replace, or
+// Delete.
+func (s *IntegrationsServiceOp) Sync(ctx context.Context, projectID string, desired []ThirdPartyIntegration, opts *SyncOptions) (*SyncResult, *Response, error) {
+	if projectID == "" {
+		return nil, nil, NewArgError("projectID", "must be set")
+	}
+
+	if opts == nil {
+		opts = &SyncOptions{}
+	}
+
+	actualList, resp, err := s.List(ctx, projectID, nil)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	actualByType := make(map[string]ThirdPartyIntegration, len(actualList.Results))
+	for _, integration := range actualList.Results {
+		actualByType[integration.Type()] = integration
+	}
+
+	desiredByType := make(map[string]ThirdPartyIntegration, len(desired))
+	types := make([]string, 0, len(desired))
+	for _, integration := range desired {
+		if integration == nil {
+			continue
+		}
+		if _, ok := desiredByType[integration.Type()]; !ok {
+			types = append(types, integration.Type())
+		}
+		desiredByType[integration.Type()] = integration
+	}
+	sort.Strings(types)
+
+	result := &SyncResult{}
+
+	for _, integrationType := range types {
+		want := desiredByType[integrationType]
+
+		have, exists := actualByType[integrationType]
+		if !exists {
+			if !opts.DryRun {
+				if _, resp, err = s.Create(ctx, projectID, want, nil); err != nil {
+					return result, resp, err
+				}
+			}
+			result.Created = append(result.Created, want)
+			continue
+		}
+
+		if integrationsEqualIgnoringSecrets(want, have) {
+			result.Unchanged = append(result.Unchanged, want)
+			continue
+		}
+
+		if !opts.DryRun {
+			if _, resp, err = s.Replace(ctx, projectID, want, nil); err != nil {
+				return result, resp, err
+			}
+		}
+		result.Updated = append(result.Updated, want)
+	}
+
+	if !opts.PreserveUnknown {
+		unknownTypes := make([]string, 0)
+		for integrationType := range actualByType {
+			if _, wanted := desiredByType[integrationType]; !wanted {
+				unknownTypes = append(unknownTypes, integrationType)
+			}
+		}
+		sort.Strings(unknownTypes)
+
+		for _, integrationType := range unknownTypes {
+			if !opts.DryRun {
+				if resp, err = s.Delete(ctx, projectID, integrationType, nil); err != nil {
+					return result, resp, err
+				}
+			}
+			result.Deleted = append(result.Deleted, actualByType[integrationType])
+		}
+	}
+
+	return result, resp, nil
+}
+
+// integrationsEqualIgnoringSecrets reports whether every field want sets
+// (after syncRedactedFields are stripped) matches the corresponding field on
+// have. Fields want omits are treated as "caller doesn't care" rather than
+// "must be zero", so server-side defaults Atlas fills in on have (e.g. an
+// unset DatadogIntegration.Region) don't cause a spurious diff.
+func integrationsEqualIgnoringSecrets(want, have ThirdPartyIntegration) bool {
+	wantFields, err := integrationFields(want)
+	if err != nil {
+		return false
+	}
+
+	haveFields, err := integrationFields(have)
+	if err != nil {
+		return false
+	}
+
+	for field, wantValue := range wantFields {
+		haveValue, ok := haveFields[field]
+		if !ok || !reflect.DeepEqual(wantValue, haveValue) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// integrationFields marshals integration to JSON and returns its fields with
+// syncRedactedFields removed.
+func integrationFields(integration ThirdPartyIntegration) (map[string]interface{}, error) {
+	data, err := json.Marshal(integration)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for field := range syncRedactedFields {
+		delete(fields, field)
+	}
+
+	return fields, nil
+}
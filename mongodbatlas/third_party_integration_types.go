@@ -0,0 +1,381 @@
+package mongodbatlas
+
+import (
+	"encoding/json"
+)
+
+// Integration type discriminators for the Atlas third-party integrations API.
+// These are the values the "type" field takes on the wire and the values
+// returned by ThirdPartyIntegration.Type().
+const (
+	IntegrationTypeDatadog        = "DATADOG"
+	IntegrationTypePagerDuty      = "PAGER_DUTY"
+	IntegrationTypeSlack          = "SLACK"
+	IntegrationTypeNewRelic       = "NEW_RELIC"
+	IntegrationTypeOpsGenie       = "OPS_GENIE"
+	IntegrationTypeVictorOps      = "VICTOR_OPS"
+	IntegrationTypeWebhook        = "WEBHOOK"
+	IntegrationTypeMicrosoftTeams = "MICROSOFT_TEAMS"
+	IntegrationTypePrometheus     = "PROMETHEUS"
+)
+
+// ThirdPartyIntegration is implemented by every typed third-party integration
+// configuration (DatadogIntegration, PagerDutyIntegration, SlackIntegration,
+// NewRelicIntegration, OpsGenieIntegration, VictorOpsIntegration,
+// WebhookIntegration, MicrosoftTeamsIntegration, PrometheusIntegration).
+//
+// Atlas discriminates the wire representation of an integration on its
+// "type" field; each concrete type marshals that field itself and Type
+// reports the same value so callers can type-switch on the result of
+// IntegrationsService.Get/List without re-parsing JSON.
+type ThirdPartyIntegration interface {
+	Type() string
+}
+
+// validatableIntegration is implemented by ThirdPartyIntegration types that
+// can check their own required fields before being sent to Atlas. Create and
+// Replace call Validate when body implements this interface.
+type validatableIntegration interface {
+	Validate() error
+}
+
+// DatadogIntegration configures the DATADOG third-party integration.
+type DatadogIntegration struct {
+	APIKey                       string `json:"apiKey"`
+	Region                       string `json:"region,omitempty"`
+	SendCollectionLatencyMetrics *bool  `json:"sendCollectionLatencyMetrics,omitempty"`
+	SendDatabaseMetrics          *bool  `json:"sendDatabaseMetrics,omitempty"`
+}
+
+// Type returns IntegrationTypeDatadog.
+func (i *DatadogIntegration) Type() string { return IntegrationTypeDatadog }
+
+// Validate checks that the fields Atlas requires for a DATADOG integration are set.
+func (i *DatadogIntegration) Validate() error {
+	if i.APIKey == "" {
+		return NewArgError("APIKey", "must be set")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, injecting the "type" discriminator
+// Atlas expects on the wire.
+func (i *DatadogIntegration) MarshalJSON() ([]byte, error) {
+	type alias DatadogIntegration
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{Type: IntegrationTypeDatadog, alias: (*alias)(i)})
+}
+
+// PagerDutyIntegration configures the PAGER_DUTY third-party integration.
+type PagerDutyIntegration struct {
+	ServiceKey string `json:"serviceKey"`
+	Region     string `json:"region,omitempty"`
+}
+
+// Type returns IntegrationTypePagerDuty.
+func (i *PagerDutyIntegration) Type() string { return IntegrationTypePagerDuty }
+
+// Validate checks that the fields Atlas requires for a PAGER_DUTY integration are set.
+func (i *PagerDutyIntegration) Validate() error {
+	if i.ServiceKey == "" {
+		return NewArgError("ServiceKey", "must be set")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, injecting the "type" discriminator
+// Atlas expects on the wire.
+func (i *PagerDutyIntegration) MarshalJSON() ([]byte, error) {
+	type alias PagerDutyIntegration
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{Type: IntegrationTypePagerDuty, alias: (*alias)(i)})
+}
+
+// SlackIntegration configures the SLACK third-party integration.
+type SlackIntegration struct {
+	APIToken    string `json:"apiToken"`
+	TeamName    string `json:"teamName,omitempty"`
+	ChannelName string `json:"channelName"`
+}
+
+// Type returns IntegrationTypeSlack.
+func (i *SlackIntegration) Type() string { return IntegrationTypeSlack }
+
+// Validate checks that the fields Atlas requires for a SLACK integration are set.
+func (i *SlackIntegration) Validate() error {
+	if i.APIToken == "" {
+		return NewArgError("APIToken", "must be set")
+	}
+	if i.ChannelName == "" {
+		return NewArgError("ChannelName", "must be set")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, injecting the "type" discriminator
+// Atlas expects on the wire.
+func (i *SlackIntegration) MarshalJSON() ([]byte, error) {
+	type alias SlackIntegration
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{Type: IntegrationTypeSlack, alias: (*alias)(i)})
+}
+
+// NewRelicIntegration configures the NEW_RELIC third-party integration.
+type NewRelicIntegration struct {
+	LicenseKey string `json:"licenseKey"`
+	AccountID  string `json:"accountId"`
+	WriteToken string `json:"writeToken"`
+	ReadToken  string `json:"readToken"`
+}
+
+// Type returns IntegrationTypeNewRelic.
+func (i *NewRelicIntegration) Type() string { return IntegrationTypeNewRelic }
+
+// Validate checks that the fields Atlas requires for a NEW_RELIC integration are set.
+func (i *NewRelicIntegration) Validate() error {
+	if i.LicenseKey == "" {
+		return NewArgError("LicenseKey", "must be set")
+	}
+	if i.AccountID == "" {
+		return NewArgError("AccountID", "must be set")
+	}
+	if i.WriteToken == "" {
+		return NewArgError("WriteToken", "must be set")
+	}
+	if i.ReadToken == "" {
+		return NewArgError("ReadToken", "must be set")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, injecting the "type" discriminator
+// Atlas expects on the wire.
+func (i *NewRelicIntegration) MarshalJSON() ([]byte, error) {
+	type alias NewRelicIntegration
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{Type: IntegrationTypeNewRelic, alias: (*alias)(i)})
+}
+
+// OpsGenieIntegration configures the OPS_GENIE third-party integration.
+type OpsGenieIntegration struct {
+	APIKey string `json:"apiKey"`
+	Region string `json:"region,omitempty"`
+}
+
+// Type returns IntegrationTypeOpsGenie.
+func (i *OpsGenieIntegration) Type() string { return IntegrationTypeOpsGenie }
+
+// Validate checks that the fields Atlas requires for an OPS_GENIE integration are set.
+func (i *OpsGenieIntegration) Validate() error {
+	if i.APIKey == "" {
+		return NewArgError("APIKey", "must be set")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, injecting the "type" discriminator
+// Atlas expects on the wire.
+func (i *OpsGenieIntegration) MarshalJSON() ([]byte, error) {
+	type alias OpsGenieIntegration
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{Type: IntegrationTypeOpsGenie, alias: (*alias)(i)})
+}
+
+// VictorOpsIntegration configures the VICTOR_OPS third-party integration.
+type VictorOpsIntegration struct {
+	APIKey     string `json:"apiKey"`
+	RoutingKey string `json:"routingKey,omitempty"`
+}
+
+// Type returns IntegrationTypeVictorOps.
+func (i *VictorOpsIntegration) Type() string { return IntegrationTypeVictorOps }
+
+// Validate checks that the fields Atlas requires for a VICTOR_OPS integration are set.
+func (i *VictorOpsIntegration) Validate() error {
+	if i.APIKey == "" {
+		return NewArgError("APIKey", "must be set")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, injecting the "type" discriminator
+// Atlas expects on the wire.
+func (i *VictorOpsIntegration) MarshalJSON() ([]byte, error) {
+	type alias VictorOpsIntegration
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{Type: IntegrationTypeVictorOps, alias: (*alias)(i)})
+}
+
+// WebhookIntegration configures the WEBHOOK third-party integration. Secret
+// is the HMAC secret Atlas uses to sign outbound alert payloads; see
+// VerifyWebhookSignature to validate them on the receiving end.
+type WebhookIntegration struct {
+	URL    string `json:"url"`
+	Secret string `json:"secret,omitempty"`
+}
+
+// Type returns IntegrationTypeWebhook.
+func (i *WebhookIntegration) Type() string { return IntegrationTypeWebhook }
+
+// Validate checks that the fields Atlas requires for a WEBHOOK integration are set.
+func (i *WebhookIntegration) Validate() error {
+	if i.URL == "" {
+		return NewArgError("URL", "must be set")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, injecting the "type" discriminator
+// Atlas expects on the wire.
+func (i *WebhookIntegration) MarshalJSON() ([]byte, error) {
+	type alias WebhookIntegration
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{Type: IntegrationTypeWebhook, alias: (*alias)(i)})
+}
+
+// MicrosoftTeamsIntegration configures the MICROSOFT_TEAMS third-party
+// integration.
+type MicrosoftTeamsIntegration struct {
+	MicrosoftTeamsWebhookURL string `json:"microsoftTeamsWebhookUrl"`
+}
+
+// Type returns IntegrationTypeMicrosoftTeams.
+func (i *MicrosoftTeamsIntegration) Type() string { return IntegrationTypeMicrosoftTeams }
+
+// Validate checks that the fields Atlas requires for a MICROSOFT_TEAMS integration are set.
+func (i *MicrosoftTeamsIntegration) Validate() error {
+	if i.MicrosoftTeamsWebhookURL == "" {
+		return NewArgError("MicrosoftTeamsWebhookURL", "must be set")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, injecting the "type" discriminator
+// Atlas expects on the wire.
+func (i *MicrosoftTeamsIntegration) MarshalJSON() ([]byte, error) {
+	type alias MicrosoftTeamsIntegration
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{Type: IntegrationTypeMicrosoftTeams, alias: (*alias)(i)})
+}
+
+// PrometheusIntegration configures the PROMETHEUS third-party integration.
+type PrometheusIntegration struct {
+	Username         string `json:"username"`
+	Password         string `json:"password"`
+	ServiceDiscovery string `json:"serviceDiscovery"`
+	Scheme           string `json:"scheme,omitempty"`
+	Enabled          *bool  `json:"enabled,omitempty"`
+	TLSPemPath       string `json:"tlsPemPath,omitempty"`
+}
+
+// Type returns IntegrationTypePrometheus.
+func (i *PrometheusIntegration) Type() string { return IntegrationTypePrometheus }
+
+// Validate checks that the fields Atlas requires for a PROMETHEUS integration are set.
+func (i *PrometheusIntegration) Validate() error {
+	if i.Username == "" {
+		return NewArgError("Username", "must be set")
+	}
+	if i.Password == "" {
+		return NewArgError("Password", "must be set")
+	}
+	if i.ServiceDiscovery == "" {
+		return NewArgError("ServiceDiscovery", "must be set")
+	}
+	if i.Scheme == "" {
+		return NewArgError("Scheme", "must be set")
+	}
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, injecting the "type" discriminator
+// Atlas expects on the wire.
+func (i *PrometheusIntegration) MarshalJSON() ([]byte, error) {
+	type alias PrometheusIntegration
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*alias
+	}{Type: IntegrationTypePrometheus, alias: (*alias)(i)})
+}
+
+// RawIntegration is the fallback ThirdPartyIntegration used for integration
+// types this package doesn't (yet) model as a concrete struct, e.g. a
+// FLOWDOCK integration configured through the deprecated ThirdPartyService.
+// It preserves the integration's raw JSON so that List/Get/Sync can report
+// and round-trip an unrecognized integration instead of failing outright.
+type RawIntegration struct {
+	// IntegrationType is the "type" discriminator as returned by Atlas.
+	IntegrationType string
+
+	// Raw is the complete JSON object Atlas returned for this integration,
+	// including its "type" field.
+	Raw json.RawMessage
+}
+
+// Type returns IntegrationType.
+func (i *RawIntegration) Type() string { return i.IntegrationType }
+
+// MarshalJSON implements json.Marshaler, returning Raw unchanged.
+func (i *RawIntegration) MarshalJSON() ([]byte, error) {
+	return i.Raw, nil
+}
+
+// unmarshalThirdPartyIntegration inspects the "type" discriminator of data
+// and unmarshals it into the matching concrete ThirdPartyIntegration. An
+// integration type this package doesn't model is returned as a
+// *RawIntegration rather than an error, so a single unrecognized integration
+// doesn't fail the rest of a List/Get call.
+func unmarshalThirdPartyIntegration(data []byte) (ThirdPartyIntegration, error) {
+	var discriminator struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &discriminator); err != nil {
+		return nil, err
+	}
+
+	var integration ThirdPartyIntegration
+	switch discriminator.Type {
+	case IntegrationTypeDatadog:
+		integration = new(DatadogIntegration)
+	case IntegrationTypePagerDuty:
+		integration = new(PagerDutyIntegration)
+	case IntegrationTypeSlack:
+		integration = new(SlackIntegration)
+	case IntegrationTypeNewRelic:
+		integration = new(NewRelicIntegration)
+	case IntegrationTypeOpsGenie:
+		integration = new(OpsGenieIntegration)
+	case IntegrationTypeVictorOps:
+		integration = new(VictorOpsIntegration)
+	case IntegrationTypeWebhook:
+		integration = new(WebhookIntegration)
+	case IntegrationTypeMicrosoftTeams:
+		integration = new(MicrosoftTeamsIntegration)
+	case IntegrationTypePrometheus:
+		integration = new(PrometheusIntegration)
+	default:
+		return &RawIntegration{IntegrationType: discriminator.Type, Raw: append(json.RawMessage(nil), data...)}, nil
+	}
+
+	if err := json.Unmarshal(data, integration); err != nil {
+		return nil, err
+	}
+
+	return integration, nil
+}
@@ -0,0 +1,99 @@
+package mongodbatlas
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	webhookSignatureHeader = "X-MMS-Signature"
+	webhookTimestampHeader = "X-MMS-Timestamp"
+
+	// DefaultWebhookSignatureSkew is the maximum age VerifyWebhookSignature
+	// allows between the X-MMS-Timestamp header and the current time before
+	// rejecting a webhook delivery as stale.
+	DefaultWebhookSignatureSkew = 5 * time.Minute
+)
+
+// VerifyWebhookSignature verifies that body was sent by Atlas for the WEBHOOK
+// integration configured with secret: it recomputes the HMAC-SHA256 of body
+// and compares it, in constant time, against the hex-encoded X-MMS-Signature
+// header, then checks the X-MMS-Timestamp header against
+// DefaultWebhookSignatureSkew to reject stale deliveries. See
+// WebhookIntegration.Secret.
+func VerifyWebhookSignature(secret string, body []byte, header http.Header) error {
+	return VerifyWebhookSignatureWithSkew(secret, body, header, DefaultWebhookSignatureSkew)
+}
+
+// VerifyWebhookSignatureWithSkew is VerifyWebhookSignature with a caller
+// supplied maximum allowed skew between the X-MMS-Timestamp header and the
+// current time. A non-positive maxSkew disables the timestamp check.
+func VerifyWebhookSignatureWithSkew(secret string, body []byte, header http.Header, maxSkew time.Duration) error {
+	sigHex := header.Get(webhookSignatureHeader)
+	if sigHex == "" {
+		return fmt.Errorf("mongodbatlas: missing %s header", webhookSignatureHeader)
+	}
+
+	sig, err := hex.DecodeString(sigHex)
+	if err != nil {
+		return fmt.Errorf("mongodbatlas: malformed %s header: %w", webhookSignatureHeader, err)
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	if !hmac.Equal(sig, expected) {
+		return errors.New("mongodbatlas: webhook signature does not match")
+	}
+
+	if maxSkew <= 0 {
+		return nil
+	}
+
+	tsHeader := header.Get(webhookTimestampHeader)
+	if tsHeader == "" {
+		return fmt.Errorf("mongodbatlas: missing %s header", webhookTimestampHeader)
+	}
+
+	unixSeconds, err := strconv.ParseInt(tsHeader, 10, 64)
+	if err != nil {
+		return fmt.Errorf("mongodbatlas: malformed %s header: %w", webhookTimestampHeader, err)
+	}
+
+	if skew := time.Since(time.Unix(unixSeconds, 0)); skew > maxSkew || skew < -maxSkew {
+		return fmt.Errorf("mongodbatlas: webhook timestamp is outside the allowed skew of %s", maxSkew)
+	}
+
+	return nil
+}
+
+// VerifyWebhookSignatureMiddleware wraps next with a handler that verifies
+// incoming requests against VerifyWebhookSignatureWithSkew before calling
+// next, rejecting unsigned, mis-signed, or stale requests with a 401. The
+// request body is restored after verification so next can read it normally.
+func VerifyWebhookSignatureMiddleware(secret string, maxSkew time.Duration, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		if err := VerifyWebhookSignatureWithSkew(secret, body, r.Header, maxSkew); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}